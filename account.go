@@ -25,48 +25,267 @@ var ErrorPreConditionCheckFailed = errors.New("pre-condition check failed")
 // a transaction failed.
 var ErrorPostConditionCheckFailed = errors.New("post-condition check failed")
 
+// ErrGasPriceExceedsCeiling indicates that a GasPriceOracle suggested a gas
+// price above the account's configured MaxGasPrice, so Transact refused to
+// submit the transaction.
+var ErrGasPriceExceedsCeiling = errors.New("gas price exceeds configured ceiling")
+
+// errNilGasPriceToBump indicates that bumpAndResend was asked to bump a gas
+// price (or fee cap) that is still nil, e.g. because a GasPriceOracle
+// returned nil and updateGasPrice left transactOpts unpriced.
+var errNilGasPriceToBump = errors.New("eth: cannot bump a nil gas price during resubmission")
+
+// GasPriceOracle suggests a gas price for legacy transactions. Account
+// composes one so operators can swap the default external gas station for
+// the connected node's own eth_gasPrice, or a fixed manual value.
+type GasPriceOracle interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// externalGasPriceOracle is the default GasPriceOracle: the external gas
+// station Account has always priced legacy transactions from.
+type externalGasPriceOracle struct{}
+
+func (externalGasPriceOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return utils.SuggestedGasPrice(), nil
+}
+
+// NodeGasPriceOracle sources the gas price from the connected node's own
+// eth_gasPrice instead of the default external gas station.
+type NodeGasPriceOracle struct {
+	Client Client
+}
+
+// SuggestGasPrice implements GasPriceOracle.
+func (o NodeGasPriceOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return o.Client.SuggestGasPrice(ctx)
+}
+
+// FixedGasPriceOracle always suggests the same manually configured price.
+type FixedGasPriceOracle struct {
+	Price *big.Int
+}
+
+// SuggestGasPrice implements GasPriceOracle.
+func (o FixedGasPriceOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return o.Price, nil
+}
+
 // Account is the Ethereum account that can perform read and write transactions
 // on the Ethereum blockchain.
 type Account struct {
-	mu     *sync.RWMutex
+	// locker serializes Transact calls per From address. A standalone
+	// Account gets its own locker; accounts added to an AccountManager
+	// share one so that only transactions from the same address block
+	// each other.
+	locker *AddrLocker
+	// nonces is the per-address local nonce cache backing retryNonceTx. It
+	// is shared the same way as locker.
+	nonces *sync.Map // map[common.Address]*big.Int
+
+	// client also doubles as the bind.ContractCaller/ContractTransactor/
+	// ContractFilterer backend for Transfer's bound contract, so any Client
+	// implementation (including the simulated subpackage's) must satisfy
+	// those in addition to eth-go's own Client methods.
 	client Client
 
+	// configMu guards the fields below, which the Set* methods can change
+	// at runtime (e.g. SetMaxGasPrice is meant to be called concurrently
+	// with in-flight Transact calls, the same way geth's miner.setGasPrice
+	// admin RPC is), against the Transact/updateGasPrice/bumpAndResend
+	// goroutines reading them.
+	configMu sync.RWMutex
+
+	// txType selects the transaction format used by updateGasPrice. It
+	// defaults to TxTypeLegacy, so existing callers are unaffected until
+	// they opt in with SetTxType.
+	txType TxType
+
+	// resubmitAfter, when non-zero, enables replace-underpriced mode: a tx
+	// that is not mined within this duration is resubmitted with a bumped
+	// gas price. Zero (the default) disables resubmission.
+	resubmitAfter time.Duration
+
+	// oracle suggests the gas price for legacy transactions. Defaults to
+	// externalGasPriceOracle; override with WithGasPriceOracle.
+	oracle GasPriceOracle
+	// minGasPrice floors the oracle's suggestion, or the computed GasFeeCap
+	// in 1559 mode. A nil floor leaves it unbounded.
+	minGasPrice *big.Int
+	// maxGasPrice ceils the oracle's suggestion, or the computed GasFeeCap
+	// in 1559 mode (Transact fails fast with ErrGasPriceExceedsCeiling above
+	// it), and also caps how far resubmission
+	// will bump the gas price or fee caps in 1559 mode. A nil ceiling
+	// leaves both unbounded.
+	maxGasPrice *big.Int
+
 	callOpts     bind.CallOpts
 	transactOpts bind.TransactOpts
 }
 
+// accountConfig is a consistent snapshot of the fields configMu guards.
+type accountConfig struct {
+	txType        TxType
+	resubmitAfter time.Duration
+	oracle        GasPriceOracle
+	minGasPrice   *big.Int
+	maxGasPrice   *big.Int
+}
+
+// config takes a consistent snapshot of the runtime-adjustable fields.
+func (account *Account) config() accountConfig {
+	account.configMu.RLock()
+	defer account.configMu.RUnlock()
+	return accountConfig{
+		txType:        account.txType,
+		resubmitAfter: account.resubmitAfter,
+		oracle:        account.oracle,
+		minGasPrice:   account.minGasPrice,
+		maxGasPrice:   account.maxGasPrice,
+	}
+}
+
+// TxType selects the Ethereum transaction format Account uses when pricing
+// and sending transactions.
+type TxType int
+
+const (
+	// TxTypeLegacy always sends type-0 transactions priced with GasPrice.
+	TxTypeLegacy TxType = iota
+	// TxTypeDynamicFee always sends type-2 (EIP-1559) transactions priced
+	// with GasFeeCap and GasTipCap.
+	TxTypeDynamicFee
+	// TxTypeAuto sends type-2 transactions when the connected chain's
+	// latest block has a BaseFee, and falls back to TxTypeLegacy pricing
+	// otherwise.
+	TxTypeAuto
+)
+
+// SetTxType changes the transaction format used by future Transact calls.
+func (account *Account) SetTxType(txType TxType) {
+	account.configMu.Lock()
+	defer account.configMu.Unlock()
+	account.txType = txType
+}
+
+// SetResubmitAfter enables replace-underpriced mode: once d passes without a
+// receipt, Transact resubmits the same logical transaction with its gas
+// price bumped. Zero (the default) disables resubmission.
+func (account *Account) SetResubmitAfter(d time.Duration) {
+	account.configMu.Lock()
+	defer account.configMu.Unlock()
+	account.resubmitAfter = d
+}
+
+// SetMaxGasPrice sets the ceiling above which Transact refuses to submit a
+// transaction (compared against the gas price, or the GasFeeCap in 1559
+// mode), and the cap on how far it will bump the gas price (or fee caps in
+// 1559 mode) while resubmitting. A nil ceiling leaves both
+// unbounded. This is the runtime equivalent of geth's miner.setGasPrice
+// admin RPC, and just like that RPC, it is safe to call while Transact calls
+// are in flight.
+func (account *Account) SetMaxGasPrice(max *big.Int) {
+	account.configMu.Lock()
+	defer account.configMu.Unlock()
+	account.maxGasPrice = max
+}
+
+// SetMinGasPrice sets the floor below which Account will not let a
+// GasPriceOracle undercut a legacy transaction's gas price, or the computed
+// GasFeeCap of a 1559 transaction fall. A nil floor leaves it unbounded.
+func (account *Account) SetMinGasPrice(min *big.Int) {
+	account.configMu.Lock()
+	defer account.configMu.Unlock()
+	account.minGasPrice = min
+}
+
+// SetGasPriceOracle swaps the GasPriceOracle used to price legacy
+// transactions.
+func (account *Account) SetGasPriceOracle(oracle GasPriceOracle) {
+	account.configMu.Lock()
+	defer account.configMu.Unlock()
+	account.oracle = oracle
+}
+
+// Option configures optional Account behavior at construction time.
+type Option func(*Account)
+
+// WithGasPriceOracle overrides the default external gas-price oracle.
+func WithGasPriceOracle(oracle GasPriceOracle) Option {
+	return func(account *Account) { account.oracle = oracle }
+}
+
+// WithMinGasPrice sets a floor below which Account will not let a
+// GasPriceOracle undercut a legacy transaction's gas price, or the computed
+// GasFeeCap of a 1559 transaction fall.
+func WithMinGasPrice(min *big.Int) Option {
+	return func(account *Account) { account.minGasPrice = min }
+}
+
+// WithMaxGasPrice sets a ceiling above which Transact fails fast with
+// ErrGasPriceExceedsCeiling instead of submitting the transaction.
+func WithMaxGasPrice(max *big.Int) Option {
+	return func(account *Account) { account.maxGasPrice = max }
+}
+
 // NewAccount returns a user account for the provided private key which is
 // connected to a ethereum client.
-func NewAccount(url string, privateKey *ecdsa.PrivateKey) (*Account, error) {
+func NewAccount(url string, privateKey *ecdsa.PrivateKey, opts ...Option) (*Account, error) {
 	client, err := Connect(url)
 	if err != nil {
 		return nil, err
 	}
 
-	transactOpts := *bind.NewKeyedTransactor(privateKey)
+	return NewAccountWithClient(client, privateKey, opts...)
+}
 
-	// Retrieve nonce and update transactOpts.
-	nonce, err := client.ethClient.PendingNonceAt(
-		context.Background(),
-		transactOpts.From)
-	if err != nil {
-		return nil, err
-	}
-	transactOpts.Nonce = big.NewInt(int64(nonce))
+// NewAccountWithClient returns a user account for the provided private key,
+// bound to client instead of dialing one from a URL. This is the entry
+// point for tests that want to swap in an in-process Client, such as the
+// one the simulated subpackage provides, instead of a live RPC endpoint.
+func NewAccountWithClient(client Client, privateKey *ecdsa.PrivateKey, opts ...Option) (*Account, error) {
+	return newAccount(client, privateKey, new(AddrLocker), new(sync.Map), opts...)
+}
+
+// newAccount builds an Account bound to client, locker and nonces. A
+// standalone NewAccount gives it sole ownership of locker and nonces; an
+// AccountManager passes in the ones it shares with every account it manages.
+func newAccount(
+	client Client,
+	privateKey *ecdsa.PrivateKey,
+	locker *AddrLocker,
+	nonces *sync.Map,
+	opts ...Option,
+) (*Account, error) {
+
+	transactOpts := *bind.NewKeyedTransactor(privateKey)
 
 	account := &Account{
-		mu:     new(sync.RWMutex),
+		locker: locker,
+		nonces: nonces,
 		client: client,
+		oracle: externalGasPriceOracle{},
 
 		callOpts:     bind.CallOpts{},
 		transactOpts: transactOpts,
 	}
 
-	account.mu.Lock()
-	defer account.mu.Unlock()
+	for _, opt := range opts {
+		opt(account)
+	}
+
+	// Seed the local nonce cache for this address.
+	if _, err := account.nonceAt(context.Background()); err != nil {
+		return nil, err
+	}
+
+	account.locker.Lock(account.Address())
+	defer account.locker.Unlock(account.Address())
 
 	// Retrieve and update transactOpts with current fast gas price
-	account.updateGasPrice()
+	if err := account.updateGasPrice(context.Background()); err != nil {
+		return nil, err
+	}
 
 	return account, nil
 }
@@ -103,19 +322,21 @@ func (account *Account) Transfer(
 		bound := bind.NewBoundContract(
 			to,
 			abi.ABI{},
-			nil,
-			account.client.ethClient,
-			nil,
+			account.client,
+			account.client,
+			account.client,
 		)
 
 		transactor := &bind.TransactOpts{
-			From:     transactOpts.From,
-			Nonce:    transactOpts.Nonce,
-			Signer:   transactOpts.Signer,
-			Value:    value,
-			GasPrice: transactOpts.GasPrice,
-			GasLimit: 21000,
-			Context:  transactOpts.Context,
+			From:      transactOpts.From,
+			Nonce:     transactOpts.Nonce,
+			Signer:    transactOpts.Signer,
+			Value:     value,
+			GasPrice:  transactOpts.GasPrice,
+			GasFeeCap: transactOpts.GasFeeCap,
+			GasTipCap: transactOpts.GasTipCap,
+			GasLimit:  21000,
+			Context:   transactOpts.Context,
 		}
 		return bound.Transfer(transactor)
 	}
@@ -141,21 +362,28 @@ func (account *Account) Transact(
 
 	for {
 		if err := func() error {
-			var err error
-			innerCtx, innerCancel := context.WithTimeout(ctx, time.Minute)
-			defer innerCancel()
-
-			account.mu.Lock()
-			defer account.mu.Unlock()
+			innerCtx := ctx
+			if account.config().resubmitAfter <= 0 {
+				var innerCancel context.CancelFunc
+				innerCtx, innerCancel = context.WithTimeout(ctx, time.Minute)
+				defer innerCancel()
+			}
 
-			account.updateGasPrice()
+			account.locker.Lock(account.Address())
+			defer account.locker.Unlock(account.Address())
 
-			tx, err := account.retryNonceTx(innerCtx, f)
+			nonce, err := account.nonceAt(innerCtx)
 			if err != nil {
 				return err
 			}
+			account.transactOpts.Nonce = nonce
+			account.transactOpts.Context = innerCtx
+
+			if err := account.updateGasPrice(innerCtx); err != nil {
+				return err
+			}
 
-			receipt, err := account.client.WaitMined(innerCtx, tx)
+			receipt, err := account.sendAndWaitMined(innerCtx, f)
 			if err != nil {
 				return err
 			}
@@ -195,19 +423,134 @@ func (account *Account) Transact(
 	}
 
 	for big.NewInt(0).Sub(currentBlockNumber, blockNumber).Cmp(big.NewInt(confirmBlocks)) < 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+		}
+
 		currentBlockNumber, err = account.client.GetCurrentBlockNumber(ctx)
 		if err != nil {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(5 * time.Millisecond):
-			}
 			continue
 		}
 	}
 	return nil
 }
 
+// sendAndWaitMined submits f via retryNonceTx and waits for its receipt. If
+// account.resubmitAfter is zero, this is just WaitMined. Otherwise, a tx
+// that is not mined within that duration is resubmitted with its gas price
+// bumped by at least 10% (the minimum geth's ErrReplaceUnderpriced accepts),
+// capped at account.maxGasPrice, and every outstanding hash races for
+// whichever confirms first. Once a winner is found (or ctx is done), every
+// other in-flight watcher is canceled rather than left polling forever.
+func (account *Account) sendAndWaitMined(
+	ctx context.Context,
+	f func(bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+
+	tx, err := account.retryNonceTx(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := account.config()
+	if cfg.resubmitAfter <= 0 {
+		return account.client.WaitMined(ctx, tx)
+	}
+
+	nonce := big.NewInt(int64(tx.Nonce()))
+
+	// watchCtx is canceled once a winner is found (or ctx itself is done),
+	// so every still-running watch goroutine for a since-replaced hash
+	// stops polling instead of leaking for the lifetime of ctx.
+	watchCtx, cancelWatchers := context.WithCancel(ctx)
+	defer cancelWatchers()
+
+	type minedResult struct {
+		receipt *types.Receipt
+		err     error
+	}
+	results := make(chan minedResult, 1)
+	watch := func(tx *types.Transaction) {
+		receipt, err := account.client.WaitMined(watchCtx, tx)
+		select {
+		case results <- minedResult{receipt, err}:
+		case <-watchCtx.Done():
+		}
+	}
+	go watch(tx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case res := <-results:
+			return res.receipt, res.err
+		case <-time.After(cfg.resubmitAfter):
+			replacement, err := account.bumpAndResend(ctx, f, nonce)
+			if err != nil {
+				continue
+			}
+			go watch(replacement)
+		}
+	}
+}
+
+// bumpAndResend resends the logical transaction at nonce with its gas price
+// (or GasFeeCap/GasTipCap in 1559 mode) bumped by at least 10%, capped at
+// account.maxGasPrice.
+func (account *Account) bumpAndResend(
+	ctx context.Context,
+	f func(bind.TransactOpts) (*types.Transaction, error),
+	nonce *big.Int,
+) (*types.Transaction, error) {
+
+	cfg := account.config()
+
+	bump := func(price *big.Int) (*big.Int, error) {
+		if price == nil {
+			return nil, errNilGasPriceToBump
+		}
+		// Round up so the bump is never below 10%.
+		bumped := new(big.Int).Mul(price, big.NewInt(11))
+		bumped.Add(bumped, big.NewInt(9))
+		bumped.Div(bumped, big.NewInt(10))
+		if cfg.maxGasPrice != nil && bumped.Cmp(cfg.maxGasPrice) > 0 {
+			bumped = cfg.maxGasPrice
+		}
+		return bumped, nil
+	}
+
+	opts := account.transactOpts
+	opts.Nonce = nonce
+	opts.Context = ctx
+	if opts.GasFeeCap != nil || opts.GasTipCap != nil {
+		feeCap, err := bump(opts.GasFeeCap)
+		if err != nil {
+			return nil, err
+		}
+		tipCap, err := bump(opts.GasTipCap)
+		if err != nil {
+			return nil, err
+		}
+		opts.GasFeeCap = feeCap
+		opts.GasTipCap = tipCap
+	} else {
+		gasPrice, err := bump(opts.GasPrice)
+		if err != nil {
+			return nil, err
+		}
+		opts.GasPrice = gasPrice
+	}
+
+	account.transactOpts.GasPrice = opts.GasPrice
+	account.transactOpts.GasFeeCap = opts.GasFeeCap
+	account.transactOpts.GasTipCap = opts.GasTipCap
+
+	return f(opts)
+}
+
 // RetryNonceTx retries
 func (account *Account) retryNonceTx(
 	ctx context.Context,
@@ -234,15 +577,20 @@ func (account *Account) retryNonceTx(
 
 	// If any other type of nonce error occurs we will refresh the nonce and
 	// try again for up to 1 minute
-	var nonce uint64
+	var pending uint64
 	for try := 0; try < 60 && strings.Contains(err.Error(), "nonce"); try++ {
-		time.Sleep(time.Second)
-		nonce, err = account.client.ethClient.PendingNonceAt(ctx,
-			account.transactOpts.From)
+		select {
+		case <-ctx.Done():
+			return tx, ctx.Err()
+		case <-time.After(time.Second):
+		}
+
+		pending, err = account.client.PendingNonceAt(ctx, account.transactOpts.From)
 		if err != nil {
 			continue
 		}
-		account.transactOpts.Nonce = big.NewInt(int64(nonce))
+		account.transactOpts.Nonce = big.NewInt(int64(pending))
+		account.nonces.Store(account.Address(), account.transactOpts.Nonce)
 		if tx, err = f(account.transactOpts); err == nil {
 			account.transactOpts.Nonce.Add(account.transactOpts.Nonce,
 				big.NewInt(1))
@@ -253,10 +601,81 @@ func (account *Account) retryNonceTx(
 	return tx, err
 }
 
-func (account *Account) updateGasPrice() {
-	gasPrice := utils.SuggestedGasPrice()
+// nonceAt returns the nonce this account should use next, seeding the local
+// cache from client.PendingNonceAt the first time it is asked for this
+// address. Later callers get the cached value, which is only bumped once a
+// transaction actually sends.
+func (account *Account) nonceAt(ctx context.Context) (*big.Int, error) {
+	addr := account.Address()
+	if nonce, ok := account.nonces.Load(addr); ok {
+		return nonce.(*big.Int), nil
+	}
+
+	pending, err := account.client.PendingNonceAt(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	nonce, _ := account.nonces.LoadOrStore(addr, big.NewInt(int64(pending)))
+	return nonce.(*big.Int), nil
+}
 
-	if gasPrice != nil {
-		account.transactOpts.GasPrice = gasPrice
+// updateGasPrice refreshes transactOpts with a current gas price, priced
+// according to account.txType. TxTypeAuto inspects the latest block header
+// and behaves as TxTypeDynamicFee when it has a BaseFee, or TxTypeLegacy
+// otherwise.
+func (account *Account) updateGasPrice(ctx context.Context) error {
+	cfg := account.config()
+
+	txType := cfg.txType
+	var header *types.Header
+	if txType == TxTypeAuto || txType == TxTypeDynamicFee {
+		var err error
+		header, err = account.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if txType == TxTypeAuto && header.BaseFee == nil {
+			txType = TxTypeLegacy
+		}
 	}
+
+	if txType == TxTypeDynamicFee {
+		tip, err := account.client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return err
+		}
+		feeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip)
+
+		if cfg.minGasPrice != nil && feeCap.Cmp(cfg.minGasPrice) < 0 {
+			feeCap = cfg.minGasPrice
+		}
+		if cfg.maxGasPrice != nil && feeCap.Cmp(cfg.maxGasPrice) > 0 {
+			return ErrGasPriceExceedsCeiling
+		}
+
+		account.transactOpts.GasTipCap = tip
+		account.transactOpts.GasFeeCap = feeCap
+		account.transactOpts.GasPrice = nil
+		return nil
+	}
+
+	gasPrice, err := cfg.oracle.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	if gasPrice == nil {
+		return nil
+	}
+
+	if cfg.minGasPrice != nil && gasPrice.Cmp(cfg.minGasPrice) < 0 {
+		gasPrice = cfg.minGasPrice
+	}
+	if cfg.maxGasPrice != nil && gasPrice.Cmp(cfg.maxGasPrice) > 0 {
+		return ErrGasPriceExceedsCeiling
+	}
+
+	account.transactOpts.GasPrice = gasPrice
+	account.transactOpts.GasFeeCap = nil
+	account.transactOpts.GasTipCap = nil
+	return nil
 }
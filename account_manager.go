@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountManager pipelines transactions from many accounts through a single
+// Client. Accounts added to the same manager share a Client, an AddrLocker
+// and a per-address nonce cache, so a Transact call from one address never
+// blocks a Transact call from a different address.
+type AccountManager struct {
+	client Client
+	locker *AddrLocker
+	nonces sync.Map // map[common.Address]*big.Int
+
+	accounts sync.Map // map[common.Address]*Account
+}
+
+// NewAccountManager connects to url once and returns a manager that every
+// account added to it will share.
+func NewAccountManager(url string) (*AccountManager, error) {
+	client, err := Connect(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountManager{
+		client: client,
+		locker: new(AddrLocker),
+	}, nil
+}
+
+// AddAccount registers privateKey with the manager and returns the Account
+// used to submit its transactions. Transactions from accounts added to the
+// same manager serialize only when they share a From address.
+func (m *AccountManager) AddAccount(privateKey *ecdsa.PrivateKey, opts ...Option) (*Account, error) {
+	account, err := newAccount(m.client, privateKey, m.locker, &m.nonces, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.accounts.Store(account.Address(), account)
+	return account, nil
+}
+
+// Account returns the account previously registered for addr, if any.
+func (m *AccountManager) Account(addr common.Address) (*Account, bool) {
+	account, ok := m.accounts.Load(addr)
+	if !ok {
+		return nil, false
+	}
+	return account.(*Account), true
+}
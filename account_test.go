@@ -0,0 +1,191 @@
+package eth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	eth "github.com/republicprotocol/eth-go"
+	"github.com/republicprotocol/eth-go/simulated"
+)
+
+// newTestKey generates a fresh private key and its address.
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+// TestNonceRecoveryAcrossAccountInstances checks that a new Account for an
+// address that already has transactions on-chain picks up where the chain
+// left off, the way it would after a process restart, instead of assuming a
+// fresh nonce of zero.
+func TestNonceRecoveryAcrossAccountInstances(t *testing.T) {
+	key, addr := newTestKey(t)
+	_, to := newTestKey(t)
+	backend := simulated.New(addr)
+	oracle := eth.NodeGasPriceOracle{Client: backend}
+
+	account, err := eth.NewAccountWithClient(backend, key, eth.WithGasPriceOracle(oracle))
+	if err != nil {
+		t.Fatalf("NewAccountWithClient: %v", err)
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- account.Transfer(context.Background(), to, big.NewInt(1), 0) }()
+	time.Sleep(20 * time.Millisecond)
+	backend.Commit()
+	if err := <-errs; err != nil {
+		t.Fatalf("first transfer: %v", err)
+	}
+
+	// A second Account for the same key, as if the process had restarted and
+	// lost its in-memory nonce cache, must still seed from the chain's
+	// pending nonce rather than nonce 0.
+	restarted, err := eth.NewAccountWithClient(backend, key, eth.WithGasPriceOracle(oracle))
+	if err != nil {
+		t.Fatalf("NewAccountWithClient after restart: %v", err)
+	}
+
+	go func() { errs <- restarted.Transfer(context.Background(), to, big.NewInt(1), 0) }()
+	time.Sleep(20 * time.Millisecond)
+	backend.Commit()
+	if err := <-errs; err != nil {
+		t.Fatalf("transfer after restart: %v", err)
+	}
+}
+
+// TestResubmissionBumpsStuckTransaction leaves a transaction unmined past
+// ResubmitAfter, so that resubmission rounds fire, and checks that Transfer
+// still returns successfully once the chain mines. It does not inspect the
+// gas price of the transaction that ends up mined.
+func TestResubmissionBumpsStuckTransaction(t *testing.T) {
+	key, addr := newTestKey(t)
+	_, to := newTestKey(t)
+	backend := simulated.New(addr)
+
+	account, err := eth.NewAccountWithClient(
+		backend, key,
+		eth.WithGasPriceOracle(eth.NodeGasPriceOracle{Client: backend}),
+		eth.WithMaxGasPrice(big.NewInt(1_000_000_000_000)),
+	)
+	if err != nil {
+		t.Fatalf("NewAccountWithClient: %v", err)
+	}
+	account.SetResubmitAfter(20 * time.Millisecond)
+
+	errs := make(chan error, 1)
+	go func() { errs <- account.Transfer(context.Background(), to, big.NewInt(1), 0) }()
+
+	// Let at least one resubmission round fire before the chain mines
+	// anything.
+	time.Sleep(60 * time.Millisecond)
+	backend.Commit()
+
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatalf("transfer after resubmission: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("transfer did not complete after resubmission")
+	}
+}
+
+// TestMaxGasPriceRejectsTooHighSuggestion checks that Account fails fast
+// instead of submitting a transaction once the oracle's suggested price
+// exceeds the configured ceiling.
+func TestMaxGasPriceRejectsTooHighSuggestion(t *testing.T) {
+	key, addr := newTestKey(t)
+	backend := simulated.New(addr)
+
+	_, err := eth.NewAccountWithClient(
+		backend, key,
+		eth.WithGasPriceOracle(eth.NodeGasPriceOracle{Client: backend}),
+		eth.WithMaxGasPrice(big.NewInt(1)),
+	)
+	if err != eth.ErrGasPriceExceedsCeiling {
+		t.Fatalf("expected ErrGasPriceExceedsCeiling, got %v", err)
+	}
+}
+
+// TestTransactWaitsForConfirmationsAndRetriesPostCondition checks the two
+// parts of Transact that the other tests' Transfer calls, with confirmBlocks
+// 0 and Transfer's fixed postConditionCheck: nil, never reach: it fails the
+// post-condition check a
+// few times before letting it succeed, and requires confirmBlocks further
+// blocks to be mined before returning.
+func TestTransactWaitsForConfirmationsAndRetriesPostCondition(t *testing.T) {
+	key, addr := newTestKey(t)
+	_, to := newTestKey(t)
+	backend := simulated.New(addr)
+
+	account, err := eth.NewAccountWithClient(backend, key, eth.WithGasPriceOracle(eth.NodeGasPriceOracle{Client: backend}))
+	if err != nil {
+		t.Fatalf("NewAccountWithClient: %v", err)
+	}
+
+	f := func(transactOpts bind.TransactOpts) (*types.Transaction, error) {
+		bound := bind.NewBoundContract(to, abi.ABI{}, backend, backend, backend)
+		return bound.Transfer(&bind.TransactOpts{
+			From:      transactOpts.From,
+			Nonce:     transactOpts.Nonce,
+			Signer:    transactOpts.Signer,
+			Value:     big.NewInt(1),
+			GasPrice:  transactOpts.GasPrice,
+			GasFeeCap: transactOpts.GasFeeCap,
+			GasTipCap: transactOpts.GasTipCap,
+			GasLimit:  21000,
+			Context:   transactOpts.Context,
+		})
+	}
+
+	var checks int32
+	postConditionCheck := func(ctx context.Context) bool {
+		return atomic.AddInt32(&checks, 1) > 2
+	}
+
+	errs := make(chan error, 1)
+	go func() { errs <- account.Transact(context.Background(), nil, f, postConditionCheck, 2) }()
+
+	// Keep mining blocks throughout so both the initial transaction and the
+	// confirmBlocks requirement can make progress however long the
+	// post-condition backoff above ends up sleeping.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(15 * time.Millisecond):
+				backend.Commit()
+			}
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Fatalf("Transact: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Transact did not complete")
+	}
+
+	if got := atomic.LoadInt32(&checks); got < 3 {
+		t.Fatalf("expected postConditionCheck to be retried at least 3 times, got %d", got)
+	}
+}
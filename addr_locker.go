@@ -0,0 +1,28 @@
+package eth
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddrLocker serializes access on a per-address basis so that operations on
+// unrelated addresses never block each other. It is safe for concurrent use.
+type AddrLocker struct {
+	locks sync.Map // map[common.Address]*sync.Mutex
+}
+
+// Lock blocks until the caller holds the lock for addr.
+func (l *AddrLocker) Lock(addr common.Address) {
+	l.lockOf(addr).Lock()
+}
+
+// Unlock releases the lock for addr.
+func (l *AddrLocker) Unlock(addr common.Address) {
+	l.lockOf(addr).Unlock()
+}
+
+func (l *AddrLocker) lockOf(addr common.Address) *sync.Mutex {
+	mu, _ := l.locks.LoadOrStore(addr, new(sync.Mutex))
+	return mu.(*sync.Mutex)
+}